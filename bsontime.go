@@ -0,0 +1,109 @@
+package mongodb
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const bsonTimeLayout = "2006-01-02 15:04:05"
+
+// bsonTimeLocation 是 BsonTime 序列化/反序列化 JSON 时使用的时区，默认东八区，
+// 可通过 SetBsonTimeLocation 覆盖。用 atomic.Value 存放，
+// 避免 SetBsonTimeLocation 与并发的 MarshalJSON/UnmarshalJSON 形成数据竞争。
+var bsonTimeLocation atomic.Value // *time.Location
+
+func init() {
+	bsonTimeLocation.Store(mustLoadLocation("Asia/Shanghai"))
+}
+
+func mustLoadLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.FixedZone(name, 8*60*60)
+	}
+	return loc
+}
+
+// SetBsonTimeLocation 设置 BsonTime 在 JSON 里格式化/解析时使用的时区，并发安全。
+func SetBsonTimeLocation(loc *time.Location) {
+	bsonTimeLocation.Store(loc)
+}
+
+// getBsonTimeLocation 返回当前生效的时区，并发安全。
+func getBsonTimeLocation() *time.Location {
+	return bsonTimeLocation.Load().(*time.Location)
+}
+
+// BsonTime 在 MongoDB 中以 primitive.DateTime 存储，在 JSON API 中以
+// "2006-01-02 15:04:05" 的本地时间字符串呈现，解决文档里时间字段存成字符串后
+// 无法范围查询的问题。
+type BsonTime time.Time
+
+// Now 返回当前时间的 BsonTime。
+func Now() BsonTime {
+	return BsonTime(time.Now())
+}
+
+// FromTime 将 time.Time 转换为 BsonTime。
+func FromTime(t time.Time) BsonTime {
+	return BsonTime(t)
+}
+
+// Parse 按 bsonTimeLocation 解析 "2006-01-02 15:04:05" 格式的字符串为 BsonTime。
+func Parse(s string) (BsonTime, error) {
+	t, err := time.ParseInLocation(bsonTimeLayout, s, getBsonTimeLocation())
+	if err != nil {
+		return BsonTime{}, err
+	}
+	return BsonTime(t), nil
+}
+
+// Time 返回底层的 time.Time。
+func (t BsonTime) Time() time.Time {
+	return time.Time(t)
+}
+
+// MarshalBSONValue 实现 bson.ValueMarshaler，在 MongoDB 中存为 primitive.DateTime。
+func (t BsonTime) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return bson.MarshalValue(primitive.NewDateTimeFromTime(time.Time(t)))
+}
+
+// UnmarshalBSONValue 实现 bson.ValueUnmarshaler，从 primitive.DateTime 还原。
+func (t *BsonTime) UnmarshalBSONValue(bt bsontype.Type, data []byte) error {
+	var dt primitive.DateTime
+	raw := bson.RawValue{Type: bt, Value: data}
+	if err := raw.Unmarshal(&dt); err != nil {
+		return err
+	}
+	*t = BsonTime(dt.Time())
+	return nil
+}
+
+// MarshalJSON 实现 json.Marshaler，按 bsonTimeLocation 格式化为 "2006-01-02 15:04:05"。
+func (t BsonTime) MarshalJSON() ([]byte, error) {
+	s := time.Time(t).In(getBsonTimeLocation()).Format(bsonTimeLayout)
+	return []byte(fmt.Sprintf("%q", s)), nil
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler，按 bsonTimeLocation 解析 "2006-01-02 15:04:05"。
+func (t *BsonTime) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		s = s[1 : len(s)-1]
+	}
+
+	parsed, err := time.ParseInLocation(bsonTimeLayout, s, getBsonTimeLocation())
+	if err != nil {
+		return err
+	}
+	*t = BsonTime(parsed)
+	return nil
+}
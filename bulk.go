@@ -0,0 +1,144 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// BulkOp 是 BulkWrite 接受的单个写操作，由 NewInsertOp 等构造函数生成。
+type BulkOp interface {
+	model() mongo.WriteModel
+}
+
+type insertOp struct{ doc interface{} }
+
+func (o insertOp) model() mongo.WriteModel {
+	return mongo.NewInsertOneModel().SetDocument(o.doc)
+}
+
+// NewInsertOp 构造一个插入操作。
+func NewInsertOp(doc interface{}) BulkOp {
+	return insertOp{doc: doc}
+}
+
+type updateOneOp struct {
+	filter, update bson.M
+	upType         UpdateType
+}
+
+func (o updateOneOp) model() mongo.WriteModel {
+	return mongo.NewUpdateOneModel().SetFilter(o.filter).SetUpdate(bson.M{o.upType.String(): o.update})
+}
+
+// NewUpdateOneOp 构造一个更新单条文档的操作。
+func NewUpdateOneOp(filter, update bson.M, upType UpdateType) BulkOp {
+	return updateOneOp{filter: filter, update: update, upType: upType}
+}
+
+type updateManyOp struct {
+	filter, update bson.M
+	upType         UpdateType
+}
+
+func (o updateManyOp) model() mongo.WriteModel {
+	return mongo.NewUpdateManyModel().SetFilter(o.filter).SetUpdate(bson.M{o.upType.String(): o.update})
+}
+
+// NewUpdateManyOp 构造一个更新多条文档的操作。
+func NewUpdateManyOp(filter, update bson.M, upType UpdateType) BulkOp {
+	return updateManyOp{filter: filter, update: update, upType: upType}
+}
+
+type replaceOneOp struct {
+	filter      bson.M
+	replacement interface{}
+}
+
+func (o replaceOneOp) model() mongo.WriteModel {
+	return mongo.NewReplaceOneModel().SetFilter(o.filter).SetReplacement(o.replacement)
+}
+
+// NewReplaceOneOp 构造一个整体替换单条文档的操作。
+func NewReplaceOneOp(filter bson.M, replacement interface{}) BulkOp {
+	return replaceOneOp{filter: filter, replacement: replacement}
+}
+
+type deleteOneOp struct{ filter bson.M }
+
+func (o deleteOneOp) model() mongo.WriteModel {
+	return mongo.NewDeleteOneModel().SetFilter(o.filter)
+}
+
+// NewDeleteOneOp 构造一个删除单条文档的操作。
+func NewDeleteOneOp(filter bson.M) BulkOp {
+	return deleteOneOp{filter: filter}
+}
+
+type deleteManyOp struct{ filter bson.M }
+
+func (o deleteManyOp) model() mongo.WriteModel {
+	return mongo.NewDeleteManyModel().SetFilter(o.filter)
+}
+
+// NewDeleteManyOp 构造一个删除多条文档的操作。
+func NewDeleteManyOp(filter bson.M) BulkOp {
+	return deleteManyOp{filter: filter}
+}
+
+// BulkWrite 在一次请求里批量执行 ops，ordered 为 true 时按顺序执行并在第一个错误处中止，
+// 为 false 时乱序并发执行、互不影响。
+func (db *Client) BulkWrite(collectionName string, ops []BulkOp, ordered bool) (*mongo.BulkWriteResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), db.ContextTimeout*time.Second)
+	defer cancel()
+
+	collection := db.SwitchCollection(ctx, collectionName)
+
+	models := make([]mongo.WriteModel, 0, len(ops))
+	for _, op := range ops {
+		models = append(models, op.model())
+	}
+
+	bulkOpts := options.BulkWrite().SetOrdered(ordered)
+	return collection.BulkWrite(ctx, models, bulkOpts)
+}
+
+// BulkWriter 把写操作攒到 batchSize 再一次性 BulkWrite，用于高吞吐的写入场景，
+// 也可以通过 Flush 主动提交未满一批的剩余操作。
+type BulkWriter struct {
+	db             *Client
+	collectionName string
+	ordered        bool
+	batchSize      int
+	ops            []BulkOp
+}
+
+// NewBulkWriter 创建一个批量写入器，ops 累积到 batchSize 时自动 Flush。
+// batchSize <= 0 表示不自动 Flush，调用方需要自行调用 Flush 提交。
+func NewBulkWriter(db *Client, collectionName string, batchSize int, ordered bool) *BulkWriter {
+	return &BulkWriter{db: db, collectionName: collectionName, ordered: ordered, batchSize: batchSize}
+}
+
+// Add 追加一个写操作，累积数量达到 batchSize 时自动 Flush；batchSize <= 0 时只攒不自动提交，
+// 需要调用方自行调用 Flush。
+func (w *BulkWriter) Add(op BulkOp) (*mongo.BulkWriteResult, error) {
+	w.ops = append(w.ops, op)
+	if w.batchSize > 0 && len(w.ops) >= w.batchSize {
+		return w.Flush()
+	}
+	return nil, nil
+}
+
+// Flush 立即提交当前累积的写操作，没有待提交操作时直接返回 nil。
+func (w *BulkWriter) Flush() (*mongo.BulkWriteResult, error) {
+	if len(w.ops) == 0 {
+		return nil, nil
+	}
+
+	result, err := w.db.BulkWrite(w.collectionName, w.ops, w.ordered)
+	w.ops = w.ops[:0]
+	return result, err
+}
@@ -0,0 +1,137 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ChangeEvent 是从 change stream 里解码出来的一条变更记录。
+type ChangeEvent struct {
+	OperationType     string              `bson:"operationType"`
+	DocumentKey       bson.Raw            `bson:"documentKey"`
+	FullDocument      bson.Raw            `bson:"fullDocument"`
+	UpdateDescription bson.Raw            `bson:"updateDescription"`
+	ClusterTime       primitive.Timestamp `bson:"clusterTime"`
+	ResumeToken       bson.Raw            `bson:"_id"`
+}
+
+// TokenStore 用于持久化 change stream 的 resume token，使 Watch 在进程重启或
+// 网络错误恢复后能从断点继续消费，而不是从头全量重放。
+type TokenStore interface {
+	Save(collection string, token bson.Raw) error
+	Load(collection string) (bson.Raw, error)
+}
+
+// WatchOptions 控制 Watch 的行为。
+type WatchOptions struct {
+	FullDocument options.FullDocument //是否在 update 事件里附带更新后的完整文档
+	TokenStore   TokenStore           //为空时不持久化 resume token，仅在当前进程内自动重连重试
+}
+
+// Watch 监听单个集合上的变更，把每个事件解码为 ChangeEvent 并交给 handler 处理。
+// handler 返回 error 时这条事件的 resume token 不会被保存或前移，Watch 会等待 1 秒后
+// 重新订阅并从同一个事件重试（包括订阅后的第一个事件），直到 handler 成功或 ctx 被
+// 取消（返回该 error）为止。遇到网络错误时同样会使用上一次成功处理的 resume token
+// 自动重新订阅。
+func (db *Client) Watch(ctx context.Context, collectionName string, pipeline []bson.M, opts WatchOptions, handler func(ChangeEvent) error) error {
+	return db.watch(ctx, func(so *options.ChangeStreamOptions) (*mongo.ChangeStream, error) {
+		return db.SwitchCollection(ctx, collectionName).Watch(ctx, toPipeline(pipeline), so)
+	}, collectionName, opts, handler)
+}
+
+// WatchDatabase 监听整个数据库上的变更。
+func (db *Client) WatchDatabase(ctx context.Context, pipeline []bson.M, opts WatchOptions, handler func(ChangeEvent) error) error {
+	return db.watch(ctx, func(so *options.ChangeStreamOptions) (*mongo.ChangeStream, error) {
+		return db.Client.Database(db.DBName).Watch(ctx, toPipeline(pipeline), so)
+	}, db.DBName, opts, handler)
+}
+
+// WatchAll 监听整个集群上的变更。
+func (db *Client) WatchAll(ctx context.Context, pipeline []bson.M, opts WatchOptions, handler func(ChangeEvent) error) error {
+	return db.watch(ctx, func(so *options.ChangeStreamOptions) (*mongo.ChangeStream, error) {
+		return db.Client.Watch(ctx, toPipeline(pipeline), so)
+	}, "", opts, handler)
+}
+
+func toPipeline(pipeline []bson.M) mongo.Pipeline {
+	stages := make(mongo.Pipeline, 0, len(pipeline))
+	for _, stage := range pipeline {
+		var d bson.D
+		for k, v := range stage {
+			d = append(d, bson.E{Key: k, Value: v})
+		}
+		stages = append(stages, d)
+	}
+	return stages
+}
+
+// watch 是 Watch/WatchDatabase/WatchAll 共用的订阅+resume循环。
+func (db *Client) watch(ctx context.Context, open func(*options.ChangeStreamOptions) (*mongo.ChangeStream, error), tokenKey string, opts WatchOptions, handler func(ChangeEvent) error) error {
+	so := options.ChangeStream()
+	if opts.FullDocument != "" {
+		so.SetFullDocument(opts.FullDocument)
+	}
+
+	if opts.TokenStore != nil {
+		if token, err := opts.TokenStore.Load(tokenKey); err == nil && token != nil {
+			so.SetResumeAfter(token)
+		}
+	}
+
+	for {
+		stream, err := open(so)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			time.Sleep(time.Second)
+			continue
+		}
+
+		// 订阅建立后、消费第一个事件前就记下初始 resume token(驱动的 postBatchResumeToken)，
+		// 这样即使第一个事件就被 handler 拒绝，重新订阅时也能从这里重放，而不是从"现在"开始
+		// 丢掉这段时间的变更。
+		if token := stream.ResumeToken(); token != nil {
+			so.SetResumeAfter(token)
+		}
+
+		err = consume(ctx, stream, tokenKey, opts, handler, so)
+		stream.Close(ctx)
+
+		if err == nil || ctx.Err() != nil {
+			return err
+		}
+		// 网络错误或 handler/TokenStore 处理失败，短暂等待后从最近一次成功的 resume token 重新订阅。
+		time.Sleep(time.Second)
+	}
+}
+
+// consume 逐条处理 stream 中的事件。一旦 handler 或 TokenStore.Save 返回 error 就立即
+// 停止并把该 error 向上返回 — resume token 只在事件成功处理并持久化之后才会前移，
+// 所以 watch() 的重试循环会从同一个失败事件重新开始，而不是跳过它。
+func consume(ctx context.Context, stream *mongo.ChangeStream, tokenKey string, opts WatchOptions, handler func(ChangeEvent) error, so *options.ChangeStreamOptions) error {
+	for stream.Next(ctx) {
+		var evt ChangeEvent
+		if err := stream.Decode(&evt); err != nil {
+			continue
+		}
+		evt.ResumeToken = stream.ResumeToken()
+
+		if err := handler(evt); err != nil {
+			return err
+		}
+
+		if opts.TokenStore != nil {
+			if err := opts.TokenStore.Save(tokenKey, evt.ResumeToken); err != nil {
+				return err
+			}
+		}
+		so.SetResumeAfter(evt.ResumeToken)
+	}
+	return stream.Err()
+}
@@ -0,0 +1,170 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Collection[T] 是 Client 之上的一层泛型封装，把 FindMany/FindManyProject 等方法
+// 返回的 []bson.Raw 换成 []T，调用方不再需要手写 bson.Unmarshal。
+// 集合名在 NewCollection 时确定一次，之后无需每次调用都传字符串。
+type Collection[T any] struct {
+	client *Client
+	name   string
+}
+
+// NewCollection 创建一个绑定到指定集合名的 Collection[T]，例如 NewCollection[User](client, "users")。
+func NewCollection[T any](client *Client, name string) *Collection[T] {
+	return &Collection[T]{client: client, name: name}
+}
+
+// raw 返回底层的 *mongo.Collection，供需要逃生到低层 API 的场景使用。
+func (c *Collection[T]) raw(ctx context.Context) *mongo.Collection {
+	return c.client.SwitchCollection(ctx, c.name)
+}
+
+// FindOne 查询一条文档并解码为 T。
+func (c *Collection[T]) FindOne(ctx context.Context, filter interface{}) (T, error) {
+	var result T
+	err := c.raw(ctx).FindOne(ctx, filter).Decode(&result)
+	return result, err
+}
+
+// FindMany 查询多条文档并解码为 []T。
+func (c *Collection[T]) FindMany(ctx context.Context, filter interface{}, opts ...*options.FindOptions) ([]T, error) {
+	cur, err := c.raw(ctx).Find(ctx, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var results []T
+	if err := cur.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Stream 查询多条文档，依次解码为 T 并交给 handler 处理，避免一次性加载全部结果到内存。
+// handler 返回 error 时会立即终止遍历并把该 error 返回给调用方。
+func (c *Collection[T]) Stream(ctx context.Context, filter interface{}, handler func(T) error, opts ...*options.FindOptions) error {
+	cur, err := c.raw(ctx).Find(ctx, filter, opts...)
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		var item T
+		if err := cur.Decode(&item); err != nil {
+			return err
+		}
+		if err := handler(item); err != nil {
+			return err
+		}
+	}
+	return cur.Err()
+}
+
+// InsertOne 插入一条 T 类型的文档。
+func (c *Collection[T]) InsertOne(ctx context.Context, value T) (*mongo.InsertOneResult, error) {
+	return c.raw(ctx).InsertOne(ctx, value)
+}
+
+// UpdateOne 按 filter 更新一条文档，updater 是不带 $set 等操作符的原始字段集合。
+func (c *Collection[T]) UpdateOne(ctx context.Context, filter bson.M, updater bson.M, upType UpdateType) (*mongo.UpdateResult, error) {
+	return c.raw(ctx).UpdateOne(ctx, filter, bson.M{upType.String(): updater})
+}
+
+// Upsert 按 filter 更新一条文档，不存在时插入。
+func (c *Collection[T]) Upsert(ctx context.Context, filter bson.M, updater bson.M, upType UpdateType) (*mongo.UpdateResult, error) {
+	opts := options.Update().SetUpsert(true)
+	return c.raw(ctx).UpdateOne(ctx, filter, bson.M{upType.String(): updater}, opts)
+}
+
+// FindOneAndUpdate 原子地更新一条文档并把更新后的结果解码为 T。
+func (c *Collection[T]) FindOneAndUpdate(ctx context.Context, filter bson.M, updater bson.M, upType UpdateType) (T, error) {
+	var result T
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err := c.raw(ctx).FindOneAndUpdate(ctx, filter, bson.M{upType.String(): updater}, opts).Decode(&result)
+	return result, err
+}
+
+// Aggregate 对集合 c 执行聚合管道，并把结果解码为 []R。R 可以和 T 不同，
+// 用于管道里带有 $group/$project 改变了文档形状的场景。
+func Aggregate[T any, R any](ctx context.Context, c *Collection[T], pipeline []bson.M) ([]R, error) {
+	cur, err := c.raw(ctx).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var results []R
+	if err := cur.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Query[T] 是一个针对 Collection[T] 的链式查询构造器，最终通过 Do 执行。
+type Query[T any] struct {
+	c          *Collection[T]
+	filter     bson.M
+	sort       bson.M
+	skip       int64
+	limit      int64
+	projection bson.D
+}
+
+// Where 在 Collection[T] 上开启一次链式查询。
+func (c *Collection[T]) Where(filter bson.M) *Query[T] {
+	return &Query[T]{c: c, filter: filter}
+}
+
+// Sort 按 key 排序，order 为 true 表示从小到大，false 表示从大到小。
+func (q *Query[T]) Sort(key string, order bool) *Query[T] {
+	orderN := -1
+	if order {
+		orderN = 1
+	}
+	q.sort = bson.M{key: orderN}
+	return q
+}
+
+// Skip 跳过前 n 条文档。
+func (q *Query[T]) Skip(n int64) *Query[T] {
+	q.skip = n
+	return q
+}
+
+// Limit 限制返回的文档数量。
+func (q *Query[T]) Limit(n int64) *Query[T] {
+	q.limit = n
+	return q
+}
+
+// Project 指定需要返回的字段。
+func (q *Query[T]) Project(keys ...string) *Query[T] {
+	projection := bson.D{}
+	for _, k := range keys {
+		projection = append(projection, bson.E{Key: k, Value: 1})
+	}
+	q.projection = projection
+	return q
+}
+
+// Do 执行查询并返回解码后的 []T。
+func (q *Query[T]) Do(ctx context.Context) ([]T, error) {
+	findOptions := options.Find().SetSkip(q.skip).SetLimit(q.limit)
+	if q.sort != nil {
+		findOptions.SetSort(q.sort)
+	}
+	if q.projection != nil {
+		findOptions.SetProjection(q.projection)
+	}
+
+	return q.c.FindMany(ctx, q.filter, findOptions)
+}
@@ -0,0 +1,172 @@
+package mongodb
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+// Config 描述建立一个 MongoDB 连接所需的全部参数，配合 NewClient 使用。
+// 设置 URI 时使用 URI 里的地址，否则使用 Hosts；其余已设置的分立字段(Username/Password、
+// ReplicaSet、TLSConfig 等)无论是否设置了 URI 都会生效，并覆盖 URI 中的同名设置。
+type Config struct {
+	URI   string   //完整连接串，设置后优先于 Hosts
+	Hosts []string //副本集节点地址列表，URI 为空时使用
+
+	Username      string
+	Password      string
+	AuthSource    string
+	AuthMechanism string
+	ReplicaSet    string
+
+	DBName         string        //数据库名称
+	ContextTimeout time.Duration //各 CRUD 方法内部 context.WithTimeout 使用的超时时间(秒)
+
+	TLSConfig              *tls.Config
+	MinPoolSize            uint64
+	MaxPoolSize            uint64
+	ConnectTimeout         time.Duration
+	ServerSelectionTimeout time.Duration
+	SocketTimeout          time.Duration
+
+	ReadPreference *readpref.ReadPref
+	ReadConcern    *readconcern.ReadConcern
+	WriteConcern   *writeconcern.WriteConcern
+	Compressors    []string
+
+	CommandMonitor *event.CommandMonitor //优先于 EnableCmdLog 内置的慢查询监控
+	PoolMonitor    *event.PoolMonitor
+
+	SlowThreshold time.Duration          //慢查询阈值，命令耗时达到该值触发 Logger
+	Logger        func(evt SlowQueryEvent) //慢查询回调，为 nil 时不做任何上报
+	EnableCmdLog  bool                   //是否开启内置慢查询监控
+}
+
+// NewClient 按 cfg 建立一个 MongoDB 连接并返回 *Client，出错时返回 error，
+// 不会像旧的 Client.New 那样在鉴权/连接失败时直接 os.Exit。
+func NewClient(cfg Config) (*Client, error) {
+	opts := options.Client()
+
+	if cfg.URI != "" {
+		opts.ApplyURI(cfg.URI)
+	} else {
+		opts.SetHosts(cfg.Hosts)
+	}
+
+	if cfg.Username != "" || cfg.Password != "" {
+		opts.SetAuth(options.Credential{
+			Username:      cfg.Username,
+			Password:      cfg.Password,
+			AuthSource:    cfg.AuthSource,
+			AuthMechanism: cfg.AuthMechanism,
+		})
+	}
+	if cfg.ReplicaSet != "" {
+		opts.SetReplicaSet(cfg.ReplicaSet)
+	}
+	if cfg.TLSConfig != nil {
+		opts.SetTLSConfig(cfg.TLSConfig)
+	}
+	if cfg.MinPoolSize > 0 {
+		opts.SetMinPoolSize(cfg.MinPoolSize)
+	}
+	if cfg.MaxPoolSize > 0 {
+		opts.SetMaxPoolSize(cfg.MaxPoolSize)
+	}
+	if cfg.ConnectTimeout > 0 {
+		opts.SetConnectTimeout(cfg.ConnectTimeout)
+	}
+	if cfg.ServerSelectionTimeout > 0 {
+		opts.SetServerSelectionTimeout(cfg.ServerSelectionTimeout)
+	}
+	if cfg.SocketTimeout > 0 {
+		opts.SetSocketTimeout(cfg.SocketTimeout)
+	}
+	if cfg.ReadPreference != nil {
+		opts.SetReadPreference(cfg.ReadPreference)
+	}
+	if cfg.ReadConcern != nil {
+		opts.SetReadConcern(cfg.ReadConcern)
+	}
+	if cfg.WriteConcern != nil {
+		opts.SetWriteConcern(cfg.WriteConcern)
+	}
+	if len(cfg.Compressors) > 0 {
+		opts.SetCompressors(cfg.Compressors)
+	}
+	if cfg.PoolMonitor != nil {
+		opts.SetPoolMonitor(cfg.PoolMonitor)
+	}
+
+	db := &Client{
+		DBName:         cfg.DBName,
+		ContextTimeout: cfg.ContextTimeout,
+		MaxPoolSize:    cfg.MaxPoolSize,
+		SlowThreshold:  cfg.SlowThreshold,
+		Logger:         cfg.Logger,
+		EnableCmdLog:   cfg.EnableCmdLog,
+		commandMonitor: cfg.CommandMonitor,
+	}
+
+	if cfg.CommandMonitor != nil {
+		opts.SetMonitor(cfg.CommandMonitor)
+	} else if monitor := db.buildCommandMonitor(); monitor != nil {
+		opts.SetMonitor(monitor)
+	}
+
+	connectTimeout := cfg.ConnectTimeout
+	if connectTimeout <= 0 {
+		connectTimeout = 10 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), connectTimeout)
+	defer cancel()
+
+	c, err := mongo.Connect(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.Ping(ctx, nil); err != nil {
+		return nil, err
+	}
+
+	db.Client = c
+	return db, nil
+}
+
+// Ping 检查与 MongoDB 的连接是否可用。
+func (db *Client) Ping(ctx context.Context) error {
+	if db.Client == nil {
+		return errors.New("mongodb: client not initialized")
+	}
+	return db.Client.Ping(ctx, nil)
+}
+
+// HealthCheck 是 Ping 的简化版本，内部按 ContextTimeout(秒) 构造超时，适合用在就绪探针里。
+func (db *Client) HealthCheck() error {
+	timeout := db.ContextTimeout * time.Second
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return db.Ping(ctx)
+}
+
+// Close 断开与 MongoDB 的连接，应在进程优雅退出时调用。
+func (db *Client) Close(ctx context.Context) error {
+	if db.Client == nil {
+		return nil
+	}
+	return db.Client.Disconnect(ctx)
+}
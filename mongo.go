@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -52,15 +53,28 @@ type Client struct {
 	DBName         string        //数据库名称
 	ContextTimeout time.Duration //context执行时间(秒)
 	MaxPoolSize    uint64        //连接池最大连接数量
+
+	SlowThreshold time.Duration          //慢查询阈值，命令耗时达到该值触发 Logger
+	Logger        func(evt SlowQueryEvent) //慢查询回调，为 nil 时不做任何上报
+	EnableCmdLog  bool                   //是否开启命令监控
+
+	commandMonitor *event.CommandMonitor //SetCommandMonitor 设置的自定义监控，优先于内置慢查询监控
 }
 
 //生成实例
+//Deprecated: 仅支持 URI+MaxPoolSize，鉴权失败会直接 os.Exit(9)。新代码请使用 NewClient(Config)。
 func (db *Client) New() {
 
 	config := new(options.ClientOptions)
 	config.ApplyURI(db.Addr)
 	config.SetMaxPoolSize(db.MaxPoolSize)
 
+	if db.commandMonitor != nil {
+		config.SetMonitor(db.commandMonitor)
+	} else if monitor := db.buildCommandMonitor(); monitor != nil {
+		config.SetMonitor(monitor)
+	}
+
 	c, err := mongo.NewClient(config)
 	if err != nil {
 		log.Printf("MongoDB 连接地址错误: %s", err.Error())
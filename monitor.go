@@ -0,0 +1,122 @@
+package mongodb
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// SlowQueryEvent 描述一次被判定为慢查询的命令执行情况。
+type SlowQueryEvent struct {
+	CommandName string        //命令名称，如 find、update、insert
+	Database    string        //目标数据库
+	Command     bson.Raw      //命令的完整 BSON 内容
+	Duration    time.Duration //命令执行耗时
+	ReplySize   int           //返回结果的字节数，失败时为 0
+	Err         error         //命令执行失败时的错误，成功为 nil
+}
+
+// commandPendingTTL 是 commandStarted 里一条记录允许存活的最长时间。
+// 连接在 Started 和 Succeeded/Failed 之间被切断(客户端 ctx 取消、断网)时事件不会再配对，
+// 靠这个 TTL 由后台 sweeper 清理，避免 commandStarted 无限增长。
+const commandPendingTTL = 5 * time.Minute
+
+type startedCommand struct {
+	evt       *event.CommandStartedEvent
+	startedAt time.Time
+}
+
+// commandStarted 记录进行中命令的 RequestID 到命令内容的映射，供 Succeeded/Failed 事件匹配耗时。
+// 这是一个进程级别的共享 map，所有开启 EnableCmdLog 的 Client 都会写入它。
+var commandStarted sync.Map
+
+// sweeperOnce 保证清理 commandStarted 里超过 commandPendingTTL 的陈旧记录的后台 goroutine
+// 在整个进程生命周期内只启动一次。
+var sweeperOnce sync.Once
+
+func ensureCommandSweeper() {
+	sweeperOnce.Do(func() {
+		go func() {
+			ticker := time.NewTicker(time.Minute)
+			defer ticker.Stop()
+			for now := range ticker.C {
+				commandStarted.Range(func(key, value interface{}) bool {
+					if sc, ok := value.(startedCommand); ok && now.Sub(sc.startedAt) > commandPendingTTL {
+						commandStarted.Delete(key)
+					}
+					return true
+				})
+			}
+		}()
+	})
+}
+
+// buildCommandMonitor 根据 db 的慢查询配置构造一个 event.CommandMonitor，
+// 在命令耗时达到 SlowThreshold 时回调 Logger。未设置 EnableCmdLog 时返回 nil。
+func (db *Client) buildCommandMonitor() *event.CommandMonitor {
+	if !db.EnableCmdLog {
+		return nil
+	}
+
+	ensureCommandSweeper()
+
+	return &event.CommandMonitor{
+		Started: func(_ context.Context, evt *event.CommandStartedEvent) {
+			commandStarted.Store(evt.RequestID, startedCommand{evt: evt, startedAt: time.Now()})
+		},
+		Succeeded: func(_ context.Context, evt *event.CommandSucceededEvent) {
+			db.reportSlowQuery(evt.RequestID, evt.DurationNanos, len(evt.Reply), nil)
+		},
+		Failed: func(_ context.Context, evt *event.CommandFailedEvent) {
+			db.reportSlowQuery(evt.RequestID, evt.DurationNanos, 0, errFromFailure(evt.Failure))
+		},
+	}
+}
+
+// reportSlowQuery 取出 requestID 对应的起始命令，若耗时达到 SlowThreshold 则交给 Logger。
+func (db *Client) reportSlowQuery(requestID int64, durationNanos int64, replySize int, err error) {
+	v, ok := commandStarted.LoadAndDelete(requestID)
+	if !ok || db.Logger == nil {
+		return
+	}
+	started := v.(startedCommand).evt
+
+	duration := time.Duration(durationNanos)
+	if duration < db.SlowThreshold {
+		return
+	}
+
+	db.Logger(SlowQueryEvent{
+		CommandName: started.CommandName,
+		Database:    started.DatabaseName,
+		Command:     started.Command,
+		Duration:    duration,
+		ReplySize:   replySize,
+		Err:         err,
+	})
+}
+
+// errFromFailure 将驱动返回的失败原因转换为 error，便于 SlowQueryEvent.Err 使用。
+func errFromFailure(failure string) error {
+	if failure == "" {
+		return nil
+	}
+	return &commandFailedError{failure}
+}
+
+type commandFailedError struct {
+	msg string
+}
+
+func (e *commandFailedError) Error() string {
+	return e.msg
+}
+
+// SetCommandMonitor 允许调用方替换默认的慢查询监控实现，接管 Started/Succeeded/Failed 事件。
+// 需要在 New 之前调用才能在建立连接时生效。
+func (db *Client) SetCommandMonitor(monitor *event.CommandMonitor) {
+	db.commandMonitor = monitor
+}
@@ -0,0 +1,257 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// WithTransaction 在一个 MongoDB 会话中执行 fn，会话内的多个操作要么全部提交要么全部回滚。
+// fn 中应使用传入的 sc 作为 ctx 调用本文件中 *WithSession 结尾的方法，以确保写入同一个事务。
+// session.WithTransaction 会按驱动建议，在遇到带 TransientTransactionError/
+// UnknownTransactionCommitResult 标签的错误时自动重试整个事务或提交。
+func (db *Client) WithTransaction(ctx context.Context, fn func(sc mongo.SessionContext) error, opts ...*options.TransactionOptions) error {
+	session, err := db.Client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
+	}, opts...)
+
+	return err
+}
+
+// StartSession 创建一个新的 mongo.Session，调用方需在使用完毕后调用其 EndSession。
+func (db *Client) StartSession() (mongo.Session, error) {
+	return db.Client.StartSession()
+}
+
+// FindOneWithSession 同 FindOne，但使用调用方传入的 ctx(通常是 WithTransaction 提供的 sc)。
+func (db *Client) FindOneWithSession(ctx mongo.SessionContext, collectionName string, filter, result interface{}) error {
+	collection := db.SwitchCollection(ctx, collectionName)
+	return collection.FindOne(ctx, filter).Decode(result)
+}
+
+// InsertOneWithSession 同 InsertOne，但使用调用方传入的 ctx。
+func (db *Client) InsertOneWithSession(ctx mongo.SessionContext, collectionName string, value interface{}) (*mongo.InsertOneResult, error) {
+	collection := db.SwitchCollection(ctx, collectionName)
+	return collection.InsertOne(ctx, value)
+}
+
+// InsertManyWithSession 同 InsertMany，但使用调用方传入的 ctx。
+func (db *Client) InsertManyWithSession(ctx mongo.SessionContext, collectionName string, value []interface{}) (*mongo.InsertManyResult, error) {
+	collection := db.SwitchCollection(ctx, collectionName)
+	return collection.InsertMany(ctx, value)
+}
+
+// UpdateOneWithSession 同 UpdateOne，但使用调用方传入的 ctx。
+func (db *Client) UpdateOneWithSession(ctx mongo.SessionContext, collectionName string, filter bson.M, updater bson.M, upType UpdateType) (*mongo.UpdateResult, error) {
+	collection := db.SwitchCollection(ctx, collectionName)
+	return collection.UpdateOne(ctx, filter, bson.M{upType.String(): updater})
+}
+
+// DeleteOneWithSession 同 DeleteOne，但使用调用方传入的 ctx。
+func (db *Client) DeleteOneWithSession(ctx mongo.SessionContext, collectionName string, filter bson.M) (*mongo.DeleteResult, error) {
+	collection := db.SwitchCollection(ctx, collectionName)
+	return collection.DeleteOne(ctx, filter)
+}
+
+// DeleteManyWithSession 同 DeleteMany，但使用调用方传入的 ctx。
+func (db *Client) DeleteManyWithSession(ctx mongo.SessionContext, collectionName string, filter bson.M) (*mongo.DeleteResult, error) {
+	collection := db.SwitchCollection(ctx, collectionName)
+	return collection.DeleteMany(ctx, filter)
+}
+
+// AggregateWithSession 同 Aggregate，但使用调用方传入的 ctx。
+func (db *Client) AggregateWithSession(ctx mongo.SessionContext, collectionName string, pipeline []bson.M) (resultRaw []bson.Raw, err error) {
+	collection := db.SwitchCollection(ctx, collectionName)
+
+	cur, err := collection.Aggregate(ctx, pipeline, options.Aggregate())
+	if err != nil {
+		return resultRaw, err
+	}
+	for cur.Next(ctx) {
+		resultRaw = append(resultRaw, cur.Current)
+	}
+
+	return resultRaw, nil
+}
+
+// FindManyWithSession 同 FindMany，但使用调用方传入的 ctx。
+func (db *Client) FindManyWithSession(ctx mongo.SessionContext, collectionName string, filter bson.M, limit int64, skip int64) (resultRaw []bson.Raw, err error) {
+	collection := db.SwitchCollection(ctx, collectionName)
+
+	findOptions := options.Find()
+	findOptions.SetLimit(limit)
+	findOptions.SetSkip(skip)
+	findOptions.SetSort(bson.M{})
+
+	cur, err := collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return resultRaw, err
+	}
+	for cur.Next(ctx) {
+		resultRaw = append(resultRaw, cur.Current)
+	}
+
+	return resultRaw, nil
+}
+
+// FindManyProjectWithSession 同 FindManyProject，但使用调用方传入的 ctx。
+func (db *Client) FindManyProjectWithSession(ctx mongo.SessionContext, collectionName string, filter bson.M, resultKeys []string, limit int64, skip int64) (resultRaw []bson.Raw, err error) {
+	collection := db.SwitchCollection(ctx, collectionName)
+
+	projection := bson.D{}
+	for _, v := range resultKeys {
+		projection = append(projection, bson.E{Key: v, Value: 1})
+	}
+
+	findOptions := options.Find()
+	findOptions.SetLimit(limit)
+	findOptions.SetSkip(skip)
+	findOptions.SetSort(bson.M{})
+	findOptions.SetProjection(projection)
+
+	cur, err := collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return resultRaw, err
+	}
+	for cur.Next(ctx) {
+		resultRaw = append(resultRaw, cur.Current)
+	}
+
+	return resultRaw, nil
+}
+
+// FindManyProjectSortWithSession 同 FindManyProjectSort，但使用调用方传入的 ctx。
+func (db *Client) FindManyProjectSortWithSession(ctx mongo.SessionContext, collectionName string, filter bson.M, resultKeys []string, sortKey string, order bool, limit int64, skip int64) (resultRaw []bson.Raw, err error) {
+	collection := db.SwitchCollection(ctx, collectionName)
+
+	projection := bson.D{}
+	for _, v := range resultKeys {
+		projection = append(projection, bson.E{Key: v, Value: 1})
+	}
+
+	orderN := -1
+	if order {
+		orderN = 1
+	}
+
+	findOptions := options.Find()
+	findOptions.SetLimit(limit)
+	findOptions.SetSkip(skip)
+	findOptions.SetSort(bson.M{sortKey: orderN})
+	findOptions.SetProjection(projection)
+
+	cur, err := collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return resultRaw, err
+	}
+	for cur.Next(ctx) {
+		resultRaw = append(resultRaw, cur.Current)
+	}
+
+	return resultRaw, nil
+}
+
+// FindManyAndSortWithSession 同 FindManyAndSort，但使用调用方传入的 ctx。
+func (db *Client) FindManyAndSortWithSession(ctx mongo.SessionContext, collectionName string, filter bson.M, sortKey string, order bool, limit int64, skip int64) (resultRaw []bson.Raw, err error) {
+	collection := db.SwitchCollection(ctx, collectionName)
+
+	orderN := -1
+	if order {
+		orderN = 1
+	}
+
+	findOptions := options.Find()
+	findOptions.SetLimit(limit)
+	findOptions.SetSkip(skip)
+	findOptions.SetSort(bson.M{sortKey: orderN})
+
+	cur, err := collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return resultRaw, err
+	}
+	for cur.Next(ctx) {
+		resultRaw = append(resultRaw, cur.Current)
+	}
+
+	return resultRaw, nil
+}
+
+// CountWithSession 同 Count，但使用调用方传入的 ctx。
+func (db *Client) CountWithSession(ctx mongo.SessionContext, collectionName string, filter bson.M) (count int64, err error) {
+	return db.SwitchCollection(ctx, collectionName).CountDocuments(ctx, filter)
+}
+
+// AllDocumentsCountWithSession 同 AllDocumentsCount，但使用调用方传入的 ctx。
+func (db *Client) AllDocumentsCountWithSession(ctx mongo.SessionContext, collectionName string) (count int64, err error) {
+	return db.SwitchCollection(ctx, collectionName).EstimatedDocumentCount(ctx)
+}
+
+// RandomOneWithSession 同 RandomOne，但使用调用方传入的 ctx。
+func (db *Client) RandomOneWithSession(ctx mongo.SessionContext, collectionName string, value interface{}) error {
+	collection := db.SwitchCollection(ctx, collectionName)
+
+	pipeline := mongo.Pipeline{{{Key: "$sample", Value: bson.M{"size": 1}}}}
+
+	cur, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+
+	for cur.Next(ctx) {
+		return cur.Decode(value)
+	}
+	return nil
+}
+
+// FindSliceWithSession 同 FindSlice，但使用调用方传入的 ctx。
+func (db *Client) FindSliceWithSession(ctx mongo.SessionContext, collectionName string, sliceName, key, value string, result interface{}) (err error) {
+	collection := db.SwitchCollection(ctx, collectionName)
+
+	filter := bson.M{sliceName: key}
+	if value != "" {
+		filter = bson.M{fmt.Sprintf("%s.%s", sliceName, key): value}
+	}
+
+	return collection.FindOne(ctx, filter).Decode(result)
+}
+
+// FindAndUpdateSetOneWithSession 同 FindAndUpdateSetOne，但使用调用方传入的 ctx，
+// 用于在 WithTransaction 内执行原子的查找后更新。
+func (db *Client) FindAndUpdateSetOneWithSession(ctx mongo.SessionContext, collectionName string, filter bson.M, updater bson.M, upType UpdateType, result interface{}) error {
+	collection := db.SwitchCollection(ctx, collectionName)
+
+	ops := new(options.FindOneAndUpdateOptions)
+	ops.SetReturnDocument(options.After)
+
+	temp := collection.FindOne(ctx, filter)
+	if temp.Err() == nil {
+		return collection.FindOneAndUpdate(ctx, filter, bson.M{upType.String(): updater}, ops).Decode(result)
+	}
+
+	return UpdateError
+}
+
+// FindAndUpdateSetIncWithSession 同 FindAndUpdateSetInc，但使用调用方传入的 ctx，
+// 用于在 WithTransaction 内执行原子的查找后更新。
+func (db *Client) FindAndUpdateSetIncWithSession(ctx mongo.SessionContext, collectionName string, filter bson.M, updater bson.M, increase bson.M, result interface{}) error {
+	collection := db.SwitchCollection(ctx, collectionName)
+
+	ops := new(options.FindOneAndUpdateOptions)
+	ops.SetReturnDocument(options.After)
+
+	temp := collection.FindOne(ctx, filter)
+	if temp.Err() == nil {
+		return collection.FindOneAndUpdate(ctx, filter, bson.M{UpdateSet.String(): updater, UpdateInc.String(): increase}, ops).Decode(result)
+	}
+
+	return UpdateError
+}